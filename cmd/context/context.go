@@ -0,0 +1,153 @@
+package context
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/solo-io/glooctl/pkg/client"
+	"github.com/solo-io/glooctl/pkg/context"
+)
+
+const (
+	flagStorage    = "storage"
+	flagKubeConfig = "kubeconfig"
+	flagNamespace  = "namespace"
+)
+
+// ContextCmd returns the `glooctl context` command tree for managing
+// named multi-cluster contexts.
+func ContextCmd(opts *client.StorageOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "manage glooctl contexts",
+	}
+	cmd.AddCommand(useCmd(), listCmd(), addCmd(), removeCmd())
+	return cmd
+}
+
+func useCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use [name]",
+		Short: "set the current context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			path, err := context.DefaultConfigPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := context.Load(path)
+			if err != nil {
+				return err
+			}
+			if _, err := cfg.Get(args[0]); err != nil {
+				return err
+			}
+			cfg.CurrentContext = args[0]
+			if err := context.Save(path, cfg); err != nil {
+				return err
+			}
+			fmt.Println("switched to context", args[0])
+			return nil
+		},
+	}
+}
+
+func listCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "list configured contexts",
+		RunE: func(c *cobra.Command, args []string) error {
+			path, err := context.DefaultConfigPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := context.Load(path)
+			if err != nil {
+				return err
+			}
+			if len(cfg.Contexts) == 0 {
+				fmt.Println("No contexts defined")
+				return nil
+			}
+			for _, ctx := range cfg.Contexts {
+				current := " "
+				if ctx.Name == cfg.CurrentContext {
+					current = "*"
+				}
+				fmt.Printf("%s %s (storage=%s namespace=%s)\n", current, ctx.Name, ctx.Storage, ctx.Namespace)
+			}
+			return nil
+		},
+	}
+}
+
+func addCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add [name]",
+		Short: "add a new context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			storageBackend, _ := c.Flags().GetString(flagStorage)
+			kubeConfig, _ := c.Flags().GetString(flagKubeConfig)
+			namespace, _ := c.Flags().GetString(flagNamespace)
+			if storageBackend == "" {
+				return errors.Errorf("--%s is required", flagStorage)
+			}
+
+			path, err := context.DefaultConfigPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := context.Load(path)
+			if err != nil {
+				return err
+			}
+			cfg.Upsert(&context.Context{
+				Name:       args[0],
+				Storage:    storageBackend,
+				KubeConfig: kubeConfig,
+				Namespace:  namespace,
+			})
+			if cfg.CurrentContext == "" {
+				cfg.CurrentContext = args[0]
+			}
+			if err := context.Save(path, cfg); err != nil {
+				return err
+			}
+			fmt.Println("added context", args[0])
+			return nil
+		},
+	}
+	cmd.Flags().String(flagStorage, "", "storage backend for this context (e.g. kubernetes, file)")
+	cmd.Flags().String(flagKubeConfig, "", "path to the kubeconfig for this context")
+	cmd.Flags().String(flagNamespace, "", "namespace to use for this context")
+	return cmd
+}
+
+func removeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [name]",
+		Short: "remove a context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			path, err := context.DefaultConfigPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := context.Load(path)
+			if err != nil {
+				return err
+			}
+			if err := cfg.Remove(args[0]); err != nil {
+				return err
+			}
+			if err := context.Save(path, cfg); err != nil {
+				return err
+			}
+			fmt.Println("removed context", args[0])
+			return nil
+		},
+	}
+}
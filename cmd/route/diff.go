@@ -0,0 +1,145 @@
+package route
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+
+	"github.com/solo-io/glooctl/pkg/client"
+	"github.com/solo-io/glooctl/pkg/context"
+	"github.com/solo-io/glooctl/pkg/diff"
+)
+
+const flagIgnore = "ignore"
+
+// diffCmd compares one or more local route definitions against the live
+// routes of a virtual host and reports any drift. It exits non-zero
+// when differences are found (after applying --ignore) so CI/GitOps
+// pipelines can gate promotion on a clean diff.
+func diffCmd(opts *client.StorageOptions) *cobra.Command {
+	var ignore string
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "diff local route definitions against the live configuration",
+		RunE: func(c *cobra.Command, args []string) error {
+			filename, _ := c.Flags().GetString(flagFilename)
+			vhostname, _ := c.Flags().GetString(flagVirtualHost)
+			domain, _ := c.Flags().GetString(flagDomain)
+			contextFlag, _ := c.Flags().GetString("context")
+
+			local, err := loadLocalRoutes(filename)
+			if err != nil {
+				return err
+			}
+
+			cfgPath, err := context.DefaultConfigPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := context.Load(cfgPath)
+			if err != nil {
+				return err
+			}
+			ctx, err := context.Resolve(cfg, contextFlag)
+			if err != nil {
+				return err
+			}
+
+			vh, _, err := virtualHost(ctx, vhostname, domain, false)
+			if err != nil {
+				return err
+			}
+
+			diffs := diff.Routes(local, vh.Routes, diff.ParseIgnore(ignore))
+			if len(diffs) == 0 {
+				fmt.Println("no differences found")
+				return nil
+			}
+			for _, d := range diffs {
+				fmt.Println(d)
+			}
+			return fmt.Errorf("%d route(s) differ from the live configuration", len(diffs))
+		},
+	}
+	cmd.Flags().StringP(flagFilename, "f", "", "local route file, directory of route files, or - for stdin")
+	cmd.Flags().String(flagVirtualHost, "", "name of the virtual host to diff against")
+	cmd.Flags().String(flagDomain, "", "domain of the virtual host to diff against")
+	cmd.Flags().String("context", "", "glooctl context to use (see `glooctl context list`)")
+	cmd.Flags().StringVar(&ignore, flagIgnore, "", "comma separated fields to ignore: matcher,destination,weights,prefix_rewrite")
+	return cmd
+}
+
+// loadLocalRoutes resolves --filename the same way parseFile does for a
+// single route, but also accepts a directory of route files or "-" for
+// stdin so diff can compare a whole virtual host's worth of routes.
+// Stdin is spooled to a temp file and run through parseFile too, so the
+// oneof Matcher/Destination fields get populated the same way as every
+// other read path.
+func loadLocalRoutes(path string) ([]*v1.Route, error) {
+	if path == "" {
+		return nil, fmt.Errorf("--%s is required", flagFilename)
+	}
+	if path == "-" {
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		tmp, err := ioutil.TempFile("", "glooctl-route-stdin-*.yaml")
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(tmp.Name())
+		if _, err := tmp.Write(b); err != nil {
+			tmp.Close()
+			return nil, err
+		}
+		if err := tmp.Close(); err != nil {
+			return nil, err
+		}
+		r, err := parseFile(tmp.Name())
+		if err != nil {
+			return nil, err
+		}
+		return []*v1.Route{r}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		r, err := parseFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []*v1.Route{r}, nil
+	}
+
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var routes []*v1.Route
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		r, err := parseFile(filepath.Join(path, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, r)
+	}
+	return routes, nil
+}
@@ -0,0 +1,53 @@
+package route
+
+import (
+	"github.com/solo-io/gloo-storage"
+	"github.com/spf13/pflag"
+
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+)
+
+// UpstreamResolver looks up the stored upstream a route should point to,
+// based on a set of resolver-specific flags. Every resolver, the static
+// one included, only matches existing upstreams and never creates one. Each Gloo-supported upstream type registers
+// its own implementation so `glooctl route create` can target any of
+// them without the user pre-creating the upstream by name.
+type UpstreamResolver interface {
+	// RegisterFlags adds this resolver's flags to the route command.
+	RegisterFlags(flags *pflag.FlagSet)
+	// Detect reports whether the user supplied flags for this resolver.
+	Detect(flags *pflag.FlagSet) bool
+	// Resolve looks up the upstream this resolver's flags describe.
+	Resolve(flags *pflag.FlagSet, sc storage.Interface) (*v1.Upstream, error)
+	// Function returns the function name to route to on the resolved
+	// upstream, if this resolver's flags name one (e.g. --aws-function).
+	// Resolvers without a function-level flag return "".
+	Function(flags *pflag.FlagSet) string
+}
+
+// upstreamResolvers lists every resolver in the order they're checked
+// when more than one set of flags could plausibly be provided.
+var upstreamResolvers = []UpstreamResolver{
+	&kubernetesResolver{},
+	&awsResolver{},
+	&consulResolver{},
+	&staticResolver{},
+}
+
+// RegisterUpstreamFlags adds every resolver's flags to the route command.
+func RegisterUpstreamFlags(flags *pflag.FlagSet) {
+	for _, r := range upstreamResolvers {
+		r.RegisterFlags(flags)
+	}
+}
+
+// detectResolver returns the resolver whose flags the user populated, or
+// nil if none were.
+func detectResolver(flags *pflag.FlagSet) UpstreamResolver {
+	for _, r := range upstreamResolvers {
+		if r.Detect(flags) {
+			return r
+		}
+	}
+	return nil
+}
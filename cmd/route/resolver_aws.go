@@ -0,0 +1,60 @@
+package route
+
+import (
+	"fmt"
+
+	"github.com/solo-io/gloo-storage"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/spf13/pflag"
+
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+)
+
+const (
+	flagAWSRegion   = "aws-region"
+	flagAWSFunction = "aws-function"
+
+	upstreamTypeAWS = "aws"
+	awsSpecRegion   = "region"
+)
+
+// awsResolver matches upstreams of type "aws" (an AWS Lambda secret/role
+// pair) by region, deferring to --aws-function for the function name on
+// the destination itself rather than the upstream lookup.
+type awsResolver struct{}
+
+func (a *awsResolver) RegisterFlags(flags *pflag.FlagSet) {
+	flags.String(flagAWSRegion, "", "AWS region of the lambda upstream")
+	flags.String(flagAWSFunction, "", "name of the lambda function to invoke")
+}
+
+func (a *awsResolver) Detect(flags *pflag.FlagSet) bool {
+	region, _ := flags.GetString(flagAWSRegion)
+	return region != ""
+}
+
+func (a *awsResolver) Function(flags *pflag.FlagSet) string {
+	fn, _ := flags.GetString(flagAWSFunction)
+	return fn
+}
+
+func (a *awsResolver) Resolve(flags *pflag.FlagSet, sc storage.Interface) (*v1.Upstream, error) {
+	region, _ := flags.GetString(flagAWSRegion)
+
+	upstreams, err := sc.V1().Upstreams().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range upstreams {
+		if u.Type != upstreamTypeAWS {
+			continue
+		}
+		s, _ := protoutil.MarshalMap(u.Spec)
+		r, exists := s[awsSpecRegion].(string)
+		if !exists || r != region {
+			continue
+		}
+		return u, nil
+	}
+	return nil, fmt.Errorf("unable to find aws upstream in region %s", region)
+}
@@ -0,0 +1,76 @@
+package route
+
+import (
+	"fmt"
+
+	"github.com/solo-io/gloo-storage"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/spf13/pflag"
+
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+)
+
+const (
+	flagConsulService = "consul-service"
+	flagConsulTag     = "consul-tag"
+
+	upstreamTypeConsul = "consul"
+	consulSpecService  = "service_name"
+	consulSpecTags     = "tags"
+)
+
+// consulResolver matches upstreams of type "consul" by service name and,
+// optionally, a tag the service must be registered with.
+type consulResolver struct{}
+
+func (c *consulResolver) RegisterFlags(flags *pflag.FlagSet) {
+	flags.String(flagConsulService, "", "name of the consul service backing the upstream")
+	flags.String(flagConsulTag, "", "consul tag the service must be registered with")
+}
+
+func (c *consulResolver) Detect(flags *pflag.FlagSet) bool {
+	service, _ := flags.GetString(flagConsulService)
+	return service != ""
+}
+
+func (c *consulResolver) Function(flags *pflag.FlagSet) string {
+	return ""
+}
+
+func (c *consulResolver) Resolve(flags *pflag.FlagSet, sc storage.Interface) (*v1.Upstream, error) {
+	service, _ := flags.GetString(flagConsulService)
+	tag, _ := flags.GetString(flagConsulTag)
+
+	upstreams, err := sc.V1().Upstreams().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range upstreams {
+		if u.Type != upstreamTypeConsul {
+			continue
+		}
+		s, _ := protoutil.MarshalMap(u.Spec)
+		name, exists := s[consulSpecService].(string)
+		if !exists || name != service {
+			continue
+		}
+		if tag != "" && !hasConsulTag(s, tag) {
+			continue
+		}
+		return u, nil
+	}
+	return nil, fmt.Errorf("unable to find consul upstream for service %s", service)
+}
+
+func hasConsulTag(spec map[string]interface{}, tag string) bool {
+	tags, ok := spec[consulSpecTags].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, t := range tags {
+		if s, ok := t.(string); ok && s == tag {
+			return true
+		}
+	}
+	return false
+}
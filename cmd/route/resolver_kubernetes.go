@@ -0,0 +1,81 @@
+package route
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/solo-io/gloo-storage"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/spf13/pflag"
+
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+)
+
+const (
+	flagKubeName      = "kube-upstream"
+	flagKubeNamespace = "kube-namespace"
+	flagKubePort      = "kube-port"
+
+	upstreamTypeKubernetes = "kubernetes"
+	kubeSpecName           = "service_name"
+	kubeSpecNamespace      = "service_namespace"
+	kubeSpecPort           = "service_port"
+)
+
+// kubernetesResolver matches upstreams of type "kubernetes" by service
+// name, namespace and port, the spec keys Gloo's Kubernetes discovery
+// plugin populates.
+type kubernetesResolver struct{}
+
+func (k *kubernetesResolver) RegisterFlags(flags *pflag.FlagSet) {
+	flags.String(flagKubeName, "", "name of the kubernetes service backing the upstream")
+	flags.String(flagKubeNamespace, "", "namespace of the kubernetes service backing the upstream")
+	flags.Int(flagKubePort, 0, "port of the kubernetes service backing the upstream")
+}
+
+func (k *kubernetesResolver) Detect(flags *pflag.FlagSet) bool {
+	name, _ := flags.GetString(flagKubeName)
+	return name != ""
+}
+
+func (k *kubernetesResolver) Function(flags *pflag.FlagSet) string {
+	return ""
+}
+
+func (k *kubernetesResolver) Resolve(flags *pflag.FlagSet, sc storage.Interface) (*v1.Upstream, error) {
+	name, _ := flags.GetString(flagKubeName)
+	namespace, _ := flags.GetString(flagKubeNamespace)
+	port, err := flags.GetInt(flagKubePort)
+	if err != nil {
+		port = 0
+	}
+
+	upstreams, err := sc.V1().Upstreams().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range upstreams {
+		if u.Type != upstreamTypeKubernetes {
+			continue
+		}
+		s, _ := protoutil.MarshalMap(u.Spec)
+		n, exists := s[kubeSpecName].(string)
+		if !exists || n != name {
+			continue
+		}
+		if namespace != "" {
+			ns, exists := s[kubeSpecNamespace].(string)
+			if !exists || ns != namespace {
+				continue
+			}
+		}
+		if port != 0 {
+			p, exists := s[kubeSpecPort].(string)
+			if !exists || p != strconv.Itoa(port) {
+				continue
+			}
+		}
+		return u, nil
+	}
+	return nil, fmt.Errorf("unable to find kubernetes upstream %s/%s", namespace, name)
+}
@@ -0,0 +1,70 @@
+package route
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/solo-io/gloo-storage"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/spf13/pflag"
+
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+)
+
+const (
+	flagStaticHost = "static-host"
+	flagStaticPort = "static-port"
+
+	upstreamTypeStatic = "static"
+	staticSpecHost     = "host"
+	staticSpecPort     = "port"
+)
+
+// staticResolver matches upstreams of type "static" (a fixed host/port
+// pair with no discovery plugin behind it) by host and port.
+type staticResolver struct{}
+
+func (s *staticResolver) RegisterFlags(flags *pflag.FlagSet) {
+	flags.String(flagStaticHost, "", "host of the static upstream")
+	flags.Int(flagStaticPort, 0, "port of the static upstream")
+}
+
+func (s *staticResolver) Detect(flags *pflag.FlagSet) bool {
+	host, _ := flags.GetString(flagStaticHost)
+	return host != ""
+}
+
+func (s *staticResolver) Function(flags *pflag.FlagSet) string {
+	return ""
+}
+
+func (s *staticResolver) Resolve(flags *pflag.FlagSet, sc storage.Interface) (*v1.Upstream, error) {
+	host, _ := flags.GetString(flagStaticHost)
+	port, err := flags.GetInt(flagStaticPort)
+	if err != nil {
+		port = 0
+	}
+
+	upstreams, err := sc.V1().Upstreams().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range upstreams {
+		if u.Type != upstreamTypeStatic {
+			continue
+		}
+		spec, _ := protoutil.MarshalMap(u.Spec)
+		h, exists := spec[staticSpecHost].(string)
+		if !exists || h != host {
+			continue
+		}
+		if port != 0 {
+			p, exists := spec[staticSpecPort].(string)
+			if !exists || p != strconv.Itoa(port) {
+				continue
+			}
+		}
+		return u, nil
+	}
+	return nil, fmt.Errorf("unable to find static upstream %s:%d", host, port)
+}
@@ -0,0 +1,91 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func flagsWith(t *testing.T, args ...string) *pflag.FlagSet {
+	t.Helper()
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	RegisterUpstreamFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("flags.Parse(%v) returned error: %v", args, err)
+	}
+	return flags
+}
+
+func TestDetectResolverNoFlagsSet(t *testing.T) {
+	if r := detectResolver(flagsWith(t)); r != nil {
+		t.Errorf("detectResolver() with no flags = %T, want nil", r)
+	}
+}
+
+func TestDetectResolverPicksResolverByFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want UpstreamResolver
+	}{
+		{"kubernetes", []string{"--kube-upstream=svc"}, &kubernetesResolver{}},
+		{"aws", []string{"--aws-region=us-east-1"}, &awsResolver{}},
+		{"consul", []string{"--consul-service=svc"}, &consulResolver{}},
+		{"static", []string{"--static-host=10.0.0.1"}, &staticResolver{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := detectResolver(flagsWith(t, tt.args...))
+			if r == nil {
+				t.Fatalf("detectResolver(%v) = nil, want %T", tt.args, tt.want)
+			}
+			got := reflectTypeName(r)
+			want := reflectTypeName(tt.want)
+			if got != want {
+				t.Errorf("detectResolver(%v) = %s, want %s", tt.args, got, want)
+			}
+		})
+	}
+}
+
+func TestDetectResolverPrefersEarlierResolverOnConflict(t *testing.T) {
+	// kubernetesResolver is checked first in upstreamResolvers, so it
+	// should win even when a later resolver's flags are also set.
+	flags := flagsWith(t, "--kube-upstream=svc", "--aws-region=us-east-1")
+	r := detectResolver(flags)
+	if _, ok := r.(*kubernetesResolver); !ok {
+		t.Errorf("detectResolver() = %T, want *kubernetesResolver", r)
+	}
+}
+
+func TestAWSResolverFunctionReadsFlag(t *testing.T) {
+	flags := flagsWith(t, "--aws-region=us-east-1", "--aws-function=my-fn")
+	r := &awsResolver{}
+	if got := r.Function(flags); got != "my-fn" {
+		t.Errorf("Function() = %q, want %q", got, "my-fn")
+	}
+}
+
+func TestNonAWSResolversHaveNoFunction(t *testing.T) {
+	flags := flagsWith(t, "--kube-upstream=svc")
+	for _, r := range []UpstreamResolver{&kubernetesResolver{}, &consulResolver{}, &staticResolver{}} {
+		if got := r.Function(flags); got != "" {
+			t.Errorf("%T.Function() = %q, want empty", r, got)
+		}
+	}
+}
+
+func reflectTypeName(r UpstreamResolver) string {
+	switch r.(type) {
+	case *kubernetesResolver:
+		return "kubernetes"
+	case *awsResolver:
+		return "aws"
+	case *consulResolver:
+		return "consul"
+	case *staticResolver:
+		return "static"
+	default:
+		return "unknown"
+	}
+}
@@ -3,9 +3,15 @@ package route
 import (
 	"bytes"
 	"fmt"
+	"math"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"text/template"
 
+	"github.com/Masterminds/sprig"
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo-storage"
 	"github.com/solo-io/gloo/pkg/protoutil"
@@ -14,6 +20,8 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/solo-io/gloo-api/pkg/api/types/v1"
 	"github.com/solo-io/gloo-storage/file"
+
+	"github.com/solo-io/glooctl/pkg/context"
 )
 
 const (
@@ -30,25 +38,11 @@ const (
 	flagUpstream      = "upstream"
 	flagFunction      = "function"
 	flagPrefixRewrite = "prefix-rewrite"
-
-	flagKubeName      = "kube-upstream"
-	flagKubeNamespace = "kube-namespace"
-	flagKubePort      = "kube-port"
+	flagDestination   = "destination"
 
 	defaultVHost = "default"
-
-	upstreamTypeKubernetes = "kubernetes"
-	kubeSpecName           = "service_name"
-	kubeSpecNamespace      = "service_namespace"
-	kubeSpecPort           = "service_port"
 )
 
-type kubeUpstream struct {
-	name      string
-	namespace string
-	port      int
-}
-
 type routeDetail struct {
 	event         string
 	pathExact     string
@@ -59,8 +53,9 @@ type routeDetail struct {
 	upstream      string
 	function      string
 	prefixRewrite string
+	destinations  []string
 
-	kube kubeUpstream
+	resolver UpstreamResolver
 }
 
 func parseFile(filename string) (*v1.Route, error) {
@@ -72,7 +67,7 @@ func parseFile(filename string) (*v1.Route, error) {
 	return &r, nil
 }
 
-func printRoutes(routes []*v1.Route, output string) {
+func printRoutes(routes []*v1.Route, output, tplt string) {
 	if len(routes) == 0 {
 		fmt.Println("No routes defined")
 		return
@@ -82,6 +77,8 @@ func printRoutes(routes []*v1.Route, output string) {
 		printJSONList(routes)
 	case "yaml":
 		printYAMLList(routes)
+	case "template":
+		printTemplateList(routes, tplt)
 	default:
 		printSummaryList(routes)
 	}
@@ -110,6 +107,34 @@ func printYAML(r *v1.Route) {
 	fmt.Println(string(b))
 }
 
+// printTemplate renders r with the user-supplied text/template, giving
+// access to the sprig function library (matching the vhost command's
+// --output template support).
+func printTemplate(r *v1.Route, tplt string) {
+	t, err := template.New("route").Funcs(sprig.TxtFuncMap()).Parse(tplt)
+	if err != nil {
+		fmt.Println("unable to parse template ", err)
+		return
+	}
+	if err := t.Execute(os.Stdout, r); err != nil {
+		fmt.Println("unable to execute template ", err)
+	}
+}
+
+// printTemplateList executes tplt once against the whole route slice,
+// letting users {{range}} over it to build a table, in addition to the
+// per-route rendering printTemplate offers.
+func printTemplateList(routes []*v1.Route, tplt string) {
+	t, err := template.New("routes").Funcs(sprig.TxtFuncMap()).Parse(tplt)
+	if err != nil {
+		fmt.Println("unable to parse template ", err)
+		return
+	}
+	if err := t.Execute(os.Stdout, routes); err != nil {
+		fmt.Println("unable to execute template ", err)
+	}
+}
+
 func printJSONList(routes []*v1.Route) {
 	for _, r := range routes {
 		printJSON(r)
@@ -208,33 +233,104 @@ func upstreamToString(u *v1.UpstreamDestination, f *v1.FunctionDestination) stri
 	return "<no destintation specified>"
 }
 
-func route(flags *pflag.FlagSet, sc storage.Interface) (*v1.Route, error) {
+func route(flags *pflag.FlagSet, ctx *context.ResolvedContext) (*v1.Route, error) {
 	filename, _ := flags.GetString(flagFilename)
 	if filename != "" {
 		return parseFile(filename)
 	}
 
 	rd := routeDetails(flags)
-	if rd.kube.name != "" {
-		upstream, err := upstream(rd.kube, sc)
+	if rd.resolver != nil {
+		upstream, err := rd.resolver.Resolve(flags, ctx.Storage)
 		if err != nil {
 			return nil, err
 		}
 		rd.upstream = upstream.Name
+		if fn := rd.resolver.Function(flags); fn != "" {
+			rd.function = fn
+		}
 	}
 	return fromRouteDetail(rd)
 }
 
+// taggedRoute pairs a route with the name of the context it was fetched
+// from, used for --all-contexts output.
+type taggedRoute struct {
+	Context string
+	Route   *v1.Route
+}
+
+// routesAllContexts fetches the vhost's routes from every resolved
+// context in parallel and tags each returned route with its origin.
+func routesAllContexts(contexts []*context.ResolvedContext, vhostname, domain string) ([]taggedRoute, error) {
+	var (
+		mu       sync.Mutex
+		tagged   []taggedRoute
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	for _, c := range contexts {
+		wg.Add(1)
+		go func(c *context.ResolvedContext) {
+			defer wg.Done()
+			vh, _, err := virtualHost(c, vhostname, domain, false)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err, "context %s", c.Name)
+				}
+				mu.Unlock()
+				return
+			}
+			mu.Lock()
+			for _, r := range vh.Routes {
+				tagged = append(tagged, taggedRoute{Context: c.Name, Route: r})
+			}
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return tagged, nil
+}
+
+func printTaggedRoutes(routes []taggedRoute, output, tplt string) {
+	if len(routes) == 0 {
+		fmt.Println("No routes defined")
+		return
+	}
+	switch output {
+	case "json":
+		for _, t := range routes {
+			fmt.Println("# context:", t.Context)
+			printJSON(t.Route)
+		}
+	case "yaml":
+		for _, t := range routes {
+			fmt.Println("# context:", t.Context)
+			printYAML(t.Route)
+		}
+	case "template":
+		for _, t := range routes {
+			fmt.Println("# context:", t.Context)
+			printTemplate(t.Route, tplt)
+		}
+	default:
+		for _, t := range routes {
+			fmt.Printf("context     : %s\n%s", t.Context, toString(t.Route))
+		}
+	}
+}
+
 func routeDetails(flags *pflag.FlagSet) *routeDetail {
 	get := func(key string) string {
 		v, _ := flags.GetString(key)
 		return v
 	}
 
-	port, err := flags.GetInt(flagKubePort)
-	if err != nil {
-		port = 0
-	}
+	destinations, _ := flags.GetStringArray(flagDestination)
 
 	return &routeDetail{
 		event:         get(flagEvent),
@@ -246,12 +342,9 @@ func routeDetails(flags *pflag.FlagSet) *routeDetail {
 		upstream:      get(flagUpstream),
 		function:      get(flagFunction),
 		prefixRewrite: get(flagPrefixRewrite),
+		destinations:  destinations,
 
-		kube: kubeUpstream{
-			name:      get(flagKubeName),
-			namespace: get(flagKubeNamespace),
-			port:      port,
-		},
+		resolver: detectResolver(flags),
 	}
 }
 
@@ -319,10 +412,21 @@ func fromRouteDetail(rd *routeDetail) (*v1.Route, error) {
 	}
 
 	// destination
+	if len(rd.destinations) > 0 {
+		if rd.upstream != "" || rd.function != "" {
+			return nil, fmt.Errorf("cannot mix --%s with --%s/--%s", flagDestination, flagUpstream, flagFunction)
+		}
+		weighted, err := weightedDestinations(rd.destinations)
+		if err != nil {
+			return nil, err
+		}
+		route.MultipleDestinations = weighted
+		return route, nil
+	}
+
 	if rd.upstream == "" {
 		return nil, fmt.Errorf("an upstream is necessary for specifying destination")
 	}
-	// currently only support single destination
 	if rd.function != "" {
 		route.SingleDestination = &v1.Destination{
 			DestinationType: &v1.Destination_Function{
@@ -344,46 +448,108 @@ func fromRouteDetail(rd *routeDetail) (*v1.Route, error) {
 	return route, nil
 }
 
-func upstream(kube kubeUpstream, sc storage.Interface) (*v1.Upstream, error) {
-	upstreams, err := sc.V1().Upstreams().List()
-	if err != nil {
-		return nil, err
-	}
-	for _, u := range upstreams {
-		if u.Type != upstreamTypeKubernetes {
-			continue
+// weightedDestinations parses --destination values of the form
+// upstream[:function]=weight into WeightedDestination entries. Weights must
+// be positive integers; if they don't already sum to 100 they are
+// normalized proportionally so they do.
+func weightedDestinations(raw []string) ([]*v1.WeightedDestination, error) {
+	type parsed struct {
+		upstream string
+		function string
+		weight   int
+	}
+	entries := make([]parsed, 0, len(raw))
+	total := 0
+	for _, d := range raw {
+		parts := strings.SplitN(d, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid --%s %q, expected upstream[:function]=weight", flagDestination, d)
 		}
-		s, _ := protoutil.MarshalMap(u.Spec)
-		n, exists := s[kubeSpecName].(string)
-		if !exists {
-			continue
+		weight, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("invalid weight in --%s %q, must be a positive integer", flagDestination, d)
 		}
-		if n != kube.name {
-			continue
+
+		dest := strings.TrimSpace(parts[0])
+		upstream, function := dest, ""
+		if idx := strings.Index(dest, ":"); idx != -1 {
+			upstream, function = dest[:idx], dest[idx+1:]
 		}
-		if kube.namespace != "" {
-			ns, exists := s[kubeSpecNamespace].(string)
-			if !exists {
-				continue
-			}
-			if ns != kube.namespace {
-				continue
-			}
+		if upstream == "" {
+			return nil, fmt.Errorf("invalid --%s %q, missing upstream", flagDestination, d)
 		}
 
-		if kube.port != 0 {
-			p, exists := s[kubeSpecPort].(string)
-			if !exists {
-				continue
+		entries = append(entries, parsed{upstream: upstream, function: function, weight: weight})
+		total += weight
+	}
+
+	rawWeights := make([]int, len(entries))
+	for i, e := range entries {
+		rawWeights[i] = e.weight
+	}
+	weights := normalizeWeights(rawWeights, total)
+
+	weighted := make([]*v1.WeightedDestination, len(entries))
+	for i, e := range entries {
+		weight := weights[i]
+
+		var destType v1.Destination_DestinationType
+		if e.function != "" {
+			destType = &v1.Destination_Function{
+				Function: &v1.FunctionDestination{UpstreamName: e.upstream, FunctionName: e.function},
 			}
-			if p != strconv.Itoa(kube.port) {
-				continue
+		} else {
+			destType = &v1.Destination_Upstream{
+				Upstream: &v1.UpstreamDestination{Name: e.upstream},
 			}
 		}
-		return u, nil
+
+		weighted[i] = &v1.WeightedDestination{
+			Destination: &v1.Destination{DestinationType: destType},
+			Weight:      uint32(weight),
+		}
 	}
-	return nil, fmt.Errorf("unable to find kubernetes upstream %s/%s", kube.namespace, kube.name)
+
+	return weighted, nil
+}
+
+// normalizeWeights scales raw to percentages that sum to exactly 100.
+// If raw already sums to 100 it's returned unchanged; otherwise every
+// entry is rounded down to a whole percent and the points still missing
+// go, one each, to the entries with the largest fractional parts.
+func normalizeWeights(raw []int, total int) []int {
+	if total == 100 {
+		return raw
+	}
+
+	type remainder struct {
+		index int
+		frac  float64
+	}
+
+	weights := make([]int, len(raw))
+	remainders := make([]remainder, len(raw))
+	assigned := 0
+	for i, w := range raw {
+		scaled := float64(w) * 100 / float64(total)
+		weights[i] = int(math.Floor(scaled))
+		remainders[i] = remainder{index: i, frac: scaled - math.Floor(scaled)}
+		assigned += weights[i]
+	}
+
+	// Largest-remainder method: floors always sum to <= 100, so hand out
+	// the shortfall one percentage point at a time to the entries whose
+	// fractional part was rounded away the most. Unlike dumping the whole
+	// remainder onto a single entry, this can never push a weight negative.
+	sort.SliceStable(remainders, func(i, j int) bool {
+		return remainders[i].frac > remainders[j].frac
+	})
+	for _, r := range remainders[:100-assigned] {
+		weights[r.index]++
+	}
+	return weights
 }
+
 func createDefaultVHost(sc storage.Interface) error {
 	vhost := &v1.VirtualHost{
 		Name: defaultVHost,
@@ -395,7 +561,8 @@ func createDefaultVHost(sc storage.Interface) error {
 	return nil
 }
 
-func virtualHost(sc storage.Interface, vhostname, domain string, create bool) (*v1.VirtualHost, bool, error) {
+func virtualHost(ctx *context.ResolvedContext, vhostname, domain string, create bool) (*v1.VirtualHost, bool, error) {
+	sc := ctx.Storage
 	// make sure default virtual host exists
 	if err := createDefaultVHost(sc); err != nil {
 		return nil, false, err
@@ -0,0 +1,79 @@
+package route
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeightedDestinationsSumsTo100(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  []string
+	}{
+		{"already 100", []string{"a=90", "b=10"}},
+		{"even thirds", []string{"a=1", "b=1", "c=1"}},
+		{"uneven", []string{"a=1", "b=2", "c=3"}},
+		{"single", []string{"a=7"}},
+		{"four destinations prone to negative remainder", []string{"a=1", "b=99", "c=99", "d=1"}},
+		{"many equal destinations", []string{"a=1", "b=1", "c=1", "d=1", "e=1", "f=1", "g=1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weighted, err := weightedDestinations(tt.raw)
+			if err != nil {
+				t.Fatalf("weightedDestinations(%v) returned error: %v", tt.raw, err)
+			}
+			sum := uint32(0)
+			for _, w := range weighted {
+				if w.GetWeight() > math.MaxInt32 {
+					t.Errorf("weightedDestinations(%v) weight %d looks like it wrapped from a negative value", tt.raw, w.GetWeight())
+				}
+				sum += w.GetWeight()
+			}
+			if sum != 100 {
+				t.Errorf("weightedDestinations(%v) weights sum to %d, want 100", tt.raw, sum)
+			}
+		})
+	}
+}
+
+func TestNormalizeWeightsNeverNegative(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   []int
+		total int
+	}{
+		{"four destinations prone to negative remainder", []int{1, 99, 99, 1}, 200},
+		{"many equal destinations", []int{1, 1, 1, 1, 1, 1, 1}, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights := normalizeWeights(tt.raw, tt.total)
+			sum := 0
+			for _, w := range weights {
+				if w < 0 {
+					t.Errorf("normalizeWeights(%v, %d) produced negative weight %d", tt.raw, tt.total, w)
+				}
+				sum += w
+			}
+			if sum != 100 {
+				t.Errorf("normalizeWeights(%v, %d) = %v, sums to %d, want 100", tt.raw, tt.total, weights, sum)
+			}
+		})
+	}
+}
+
+func TestWeightedDestinationsRejectsInvalid(t *testing.T) {
+	tests := [][]string{
+		{"a"},
+		{"a=notanumber"},
+		{"a=0"},
+		{"=50"},
+	}
+	for _, raw := range tests {
+		if _, err := weightedDestinations(raw); err == nil {
+			t.Errorf("weightedDestinations(%v) expected error, got nil", raw)
+		}
+	}
+}
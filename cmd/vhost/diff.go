@@ -0,0 +1,99 @@
+package vhost
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+	"github.com/solo-io/gloo-storage/file"
+
+	"github.com/solo-io/glooctl/pkg/client"
+	"github.com/solo-io/glooctl/pkg/context"
+	"github.com/solo-io/glooctl/pkg/diff"
+)
+
+// diffCmd compares a local virtual host definition (domains and routes)
+// against the live configuration, reusing the same semantic diff engine
+// as `glooctl route diff`.
+func diffCmd(opts *client.StorageOptions) *cobra.Command {
+	var (
+		filename string
+		ignore   string
+	)
+	cmd := &cobra.Command{
+		Use:   "diff [name]",
+		Short: "diff a local virtual host definition against the live configuration",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if filename == "" {
+				return fmt.Errorf("--filename is required")
+			}
+			var local v1.VirtualHost
+			if err := file.ReadFileInto(filename, &local); err != nil {
+				return err
+			}
+
+			cfgPath, err := context.DefaultConfigPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := context.Load(cfgPath)
+			if err != nil {
+				return err
+			}
+			ctx, err := context.Resolve(cfg, contextName)
+			if err != nil {
+				return err
+			}
+
+			live, err := ctx.Storage.V1().VirtualHosts().Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			domainDiffs := diffDomains(local.Domains, live.Domains)
+			routeDiffs := diff.Routes(local.Routes, live.Routes, diff.ParseIgnore(ignore))
+			if len(domainDiffs) == 0 && len(routeDiffs) == 0 {
+				fmt.Println("no differences found")
+				return nil
+			}
+
+			for _, d := range domainDiffs {
+				fmt.Println(d)
+			}
+			for _, d := range routeDiffs {
+				fmt.Println(d)
+			}
+			return fmt.Errorf("virtual host %s differs from the live configuration", args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "local virtual host file")
+	cmd.Flags().StringVar(&ignore, "ignore", "", "comma separated route fields to ignore: matcher,destination,weights,prefix_rewrite")
+	return cmd
+}
+
+// diffDomains reports domains present in only one of local/live.
+func diffDomains(local, live []string) []string {
+	liveSet := make(map[string]bool, len(live))
+	for _, d := range live {
+		liveSet[d] = true
+	}
+	localSet := make(map[string]bool, len(local))
+	for _, d := range local {
+		localSet[d] = true
+	}
+
+	var diffs []string
+	for _, d := range local {
+		if !liveSet[d] {
+			diffs = append(diffs, "+ domain "+d)
+		}
+	}
+	for _, d := range live {
+		if !localSet[d] {
+			diffs = append(diffs, "- domain "+d)
+		}
+	}
+	return diffs
+}
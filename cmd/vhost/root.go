@@ -6,8 +6,10 @@ import (
 )
 
 var (
-	output string
-	tplt   string
+	output      string
+	tplt        string
+	contextName string
+	allContexts bool
 )
 
 func VHostCmd(opts *client.StorageOptions) *cobra.Command {
@@ -18,7 +20,9 @@ func VHostCmd(opts *client.StorageOptions) *cobra.Command {
 	pflags := cmd.PersistentFlags()
 	pflags.StringVarP(&output, "output", "o", "", "output format yaml|json|template")
 	pflags.StringVarP(&tplt, "template", "t", "", "output template")
+	pflags.StringVar(&contextName, "context", "", "glooctl context to use (see `glooctl context list`)")
+	pflags.BoolVar(&allContexts, "all-contexts", false, "fan out to every configured context and tag results with their origin")
 	cmd.AddCommand(createCmd(opts), deleteCmd(opts), getCmd(opts),
-		updateCmd(opts), editCmd(opts))
+		updateCmd(opts), editCmd(opts), diffCmd(opts))
 	return cmd
 }
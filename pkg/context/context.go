@@ -0,0 +1,179 @@
+// Package context manages named glooctl contexts, each pointing at a
+// storage backend/kubeconfig/namespace combination, analogous to a
+// kubectl context. Contexts are persisted to ~/.glooctl/config so they
+// survive across invocations.
+package context
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo-storage"
+
+	"github.com/solo-io/glooctl/pkg/client"
+)
+
+// Context is a single named storage target.
+type Context struct {
+	Name       string `json:"name"`
+	Storage    string `json:"storage"`
+	KubeConfig string `json:"kubeConfig,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+}
+
+// Config is the contents of ~/.glooctl/config.
+type Config struct {
+	CurrentContext string     `json:"currentContext"`
+	Contexts       []*Context `json:"contexts"`
+}
+
+// DefaultConfigPath returns the location glooctl reads/writes its context
+// config from, ~/.glooctl/config.
+func DefaultConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to determine home directory")
+	}
+	return filepath.Join(home, ".glooctl", "config"), nil
+}
+
+// Load reads the context config from path. A missing file is treated as
+// an empty config rather than an error so a fresh install works out of
+// the box with a single, unnamed context.
+func Load(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read context config")
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, errors.Wrap(err, "unable to parse context config")
+	}
+	return &cfg, nil
+}
+
+// Save writes cfg to path, creating its parent directory if necessary.
+func Save(path string, cfg *Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "unable to create config directory")
+	}
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal context config")
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// Get returns the named context, or an error if it doesn't exist.
+func (c *Config) Get(name string) (*Context, error) {
+	for _, ctx := range c.Contexts {
+		if ctx.Name == name {
+			return ctx, nil
+		}
+	}
+	return nil, errors.Errorf("context %s not found", name)
+}
+
+// Upsert adds ctx or replaces the existing context with the same name.
+func (c *Config) Upsert(ctx *Context) {
+	for i, existing := range c.Contexts {
+		if existing.Name == ctx.Name {
+			c.Contexts[i] = ctx
+			return
+		}
+	}
+	c.Contexts = append(c.Contexts, ctx)
+}
+
+// Remove deletes the named context and, if it was current, clears
+// CurrentContext.
+func (c *Config) Remove(name string) error {
+	for i, ctx := range c.Contexts {
+		if ctx.Name == name {
+			c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+			if c.CurrentContext == name {
+				c.CurrentContext = ""
+			}
+			return nil
+		}
+	}
+	return errors.Errorf("context %s not found", name)
+}
+
+// ResolvedContext pairs a context name with the storage client it
+// resolves to, so commands that operate --all-contexts can tag results
+// with their origin.
+type ResolvedContext struct {
+	Name    string
+	Storage storage.Interface
+}
+
+// Resolve looks up the named context (falling back to cfg.CurrentContext
+// when name is empty) and connects its storage client.
+func Resolve(cfg *Config, name string) (*ResolvedContext, error) {
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	if name == "" {
+		// no contexts configured, fall back to the default storage opts
+		sc, err := client.Storage(&client.StorageOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &ResolvedContext{Name: "default", Storage: sc}, nil
+	}
+
+	ctx, err := cfg.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := client.Storage(&client.StorageOptions{
+		Type:       ctx.Storage,
+		KubeConfig: ctx.KubeConfig,
+		Namespace:  ctx.Namespace,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to context %s", ctx.Name)
+	}
+	return &ResolvedContext{Name: ctx.Name, Storage: sc}, nil
+}
+
+// ResolveAll connects a ResolvedContext for every configured context, for
+// --all-contexts fan-out. If no contexts are configured it resolves the
+// single default context.
+func ResolveAll(cfg *Config) ([]*ResolvedContext, error) {
+	if len(cfg.Contexts) == 0 {
+		resolved, err := Resolve(cfg, "")
+		if err != nil {
+			return nil, err
+		}
+		return []*ResolvedContext{resolved}, nil
+	}
+
+	resolved := make([]*ResolvedContext, len(cfg.Contexts))
+	errs := make([]error, len(cfg.Contexts))
+	done := make(chan int, len(cfg.Contexts))
+	for i, ctx := range cfg.Contexts {
+		go func(i int, name string) {
+			r, err := Resolve(cfg, name)
+			resolved[i] = r
+			errs[i] = err
+			done <- i
+		}(i, ctx.Name)
+	}
+	for range cfg.Contexts {
+		<-done
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,96 @@
+package context
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigGetUpsertRemove(t *testing.T) {
+	cfg := &Config{}
+	cfg.Upsert(&Context{Name: "a", Storage: "kubernetes"})
+	cfg.Upsert(&Context{Name: "b", Storage: "consul"})
+
+	got, err := cfg.Get("a")
+	if err != nil {
+		t.Fatalf("Get(a) returned error: %v", err)
+	}
+	if got.Storage != "kubernetes" {
+		t.Errorf("Get(a).Storage = %q, want %q", got.Storage, "kubernetes")
+	}
+
+	// Upsert with an existing name replaces rather than appends.
+	cfg.Upsert(&Context{Name: "a", Storage: "file"})
+	if len(cfg.Contexts) != 2 {
+		t.Fatalf("len(Contexts) = %d after re-upserting \"a\", want 2", len(cfg.Contexts))
+	}
+	got, _ = cfg.Get("a")
+	if got.Storage != "file" {
+		t.Errorf("Get(a).Storage after re-upsert = %q, want %q", got.Storage, "file")
+	}
+
+	if _, err := cfg.Get("missing"); err == nil {
+		t.Error("Get(missing) returned nil error, want an error")
+	}
+
+	if err := cfg.Remove("a"); err != nil {
+		t.Fatalf("Remove(a) returned error: %v", err)
+	}
+	if _, err := cfg.Get("a"); err == nil {
+		t.Error("Get(a) after Remove(a) returned nil error, want an error")
+	}
+	if err := cfg.Remove("a"); err == nil {
+		t.Error("Remove(a) twice returned nil error, want an error")
+	}
+}
+
+func TestConfigRemoveClearsCurrentContext(t *testing.T) {
+	cfg := &Config{CurrentContext: "a"}
+	cfg.Upsert(&Context{Name: "a", Storage: "kubernetes"})
+
+	if err := cfg.Remove("a"); err != nil {
+		t.Fatalf("Remove(a) returned error: %v", err)
+	}
+	if cfg.CurrentContext != "" {
+		t.Errorf("CurrentContext = %q after removing it, want empty", cfg.CurrentContext)
+	}
+}
+
+func TestLoadMissingFileReturnsEmptyConfig(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err != nil {
+		t.Fatalf("Load(missing) returned error: %v", err)
+	}
+	if cfg.CurrentContext != "" || len(cfg.Contexts) != 0 {
+		t.Errorf("Load(missing) = %+v, want an empty config", cfg)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "glooctl", "config")
+
+	cfg := &Config{CurrentContext: "a"}
+	cfg.Upsert(&Context{Name: "a", Storage: "kubernetes", Namespace: "gloo-system"})
+	cfg.Upsert(&Context{Name: "b", Storage: "consul"})
+
+	if err := Save(path, cfg); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if loaded.CurrentContext != cfg.CurrentContext {
+		t.Errorf("Load().CurrentContext = %q, want %q", loaded.CurrentContext, cfg.CurrentContext)
+	}
+	if len(loaded.Contexts) != len(cfg.Contexts) {
+		t.Fatalf("Load() has %d contexts, want %d", len(loaded.Contexts), len(cfg.Contexts))
+	}
+	got, err := loaded.Get("a")
+	if err != nil {
+		t.Fatalf("Load().Get(a) returned error: %v", err)
+	}
+	if got.Namespace != "gloo-system" {
+		t.Errorf("Load().Get(a).Namespace = %q, want %q", got.Namespace, "gloo-system")
+	}
+}
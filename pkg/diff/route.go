@@ -0,0 +1,371 @@
+// Package diff computes semantic, field-level differences between
+// locally declared Gloo routes/virtual hosts and what's actually live in
+// storage, so `glooctl route diff` / `glooctl virtualhost diff` can gate
+// GitOps promotion on a clean diff.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+)
+
+// ChangeType describes how a route differs between local and live.
+type ChangeType string
+
+const (
+	Added   ChangeType = "added"
+	Removed ChangeType = "removed"
+	Changed ChangeType = "changed"
+)
+
+// FieldDiff is a unified diff for a single field of a changed route.
+// Field is one of "matcher", "destination", "weights", "prefix_rewrite".
+type FieldDiff struct {
+	Field string
+	Diff  string
+}
+
+// RouteDiff describes one route's drift between local and live.
+type RouteDiff struct {
+	Type   ChangeType
+	Local  *v1.Route
+	Live   *v1.Route
+	Fields []FieldDiff
+}
+
+// String renders the diff as a single line for added/removed routes, or
+// a summary line followed by one unified diff per changed field.
+func (d RouteDiff) String() string {
+	switch d.Type {
+	case Added:
+		return fmt.Sprintf("+ %s", Describe(d.Local))
+	case Removed:
+		return fmt.Sprintf("- %s", Describe(d.Live))
+	default:
+		b := strings.Builder{}
+		fmt.Fprintf(&b, "~ %s\n", Describe(d.Local))
+		for _, f := range d.Fields {
+			fmt.Fprintf(&b, "  %s:\n%s\n", f.Field, f.Diff)
+		}
+		return b.String()
+	}
+}
+
+// ParseIgnore turns a comma separated --ignore flag value into a lookup
+// set of field names (matcher, destination, weights, prefix_rewrite).
+func ParseIgnore(s string) map[string]bool {
+	ignore := make(map[string]bool)
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f != "" {
+			ignore[f] = true
+		}
+	}
+	return ignore
+}
+
+// Describe returns a short, human readable identifier for a route,
+// suitable for labeling a diff entry.
+func Describe(r *v1.Route) string {
+	switch m := r.GetMatcher().(type) {
+	case *v1.Route_EventMatcher:
+		return "event " + m.EventMatcher.EventType
+	case *v1.Route_RequestMatcher:
+		switch p := m.RequestMatcher.GetPath().(type) {
+		case *v1.RequestMatcher_PathExact:
+			return "path exact " + p.PathExact
+		case *v1.RequestMatcher_PathRegex:
+			return "path regex " + p.PathRegex
+		case *v1.RequestMatcher_PathPrefix:
+			return "path prefix " + p.PathPrefix
+		}
+	}
+	return "unknown matcher"
+}
+
+// Routes compares local (desired) routes against live routes. Entries
+// are first matched by their exact matcher (so reordering alone isn't
+// reported as drift). Anything left over is grouped with leftovers of the
+// same matcher *kind* (e.g. two path-prefix matchers) and paired up by
+// shared destinations, so an edited matcher (say a path-prefix changed
+// from /v1 to /v2 but still pointing at the same upstream) surfaces as a
+// single Changed entry with a "matcher" field diff rather than an
+// unrelated add+remove pair. Leftovers that share no destination are
+// never paired, so two unrelated routes that happen to share a matcher
+// kind still show up as a plain remove/add. Field names present in
+// ignore are left out of the comparison; "matcher" included in ignore
+// suppresses matcher-only edits entirely instead of just hiding their
+// diff text.
+func Routes(local, live []*v1.Route, ignore map[string]bool) []RouteDiff {
+	liveByKey := indexByMatcher(live)
+	usedLive := make(map[string]bool, len(live))
+
+	var diffs []RouteDiff
+	var leftoverLocal []*v1.Route
+	for _, l := range local {
+		key := matcherKey(l)
+		r, ok := liveByKey[key]
+		if !ok || usedLive[key] {
+			leftoverLocal = append(leftoverLocal, l)
+			continue
+		}
+		usedLive[key] = true
+		if fields := compareRoute(l, r, false, ignore); len(fields) > 0 {
+			diffs = append(diffs, RouteDiff{Type: Changed, Local: l, Live: r, Fields: fields})
+		}
+	}
+
+	var leftoverLive []*v1.Route
+	for _, r := range live {
+		if usedLive[matcherKey(r)] {
+			continue
+		}
+		leftoverLive = append(leftoverLive, r)
+	}
+
+	diffs = append(diffs, pairByMatcherKind(leftoverLocal, leftoverLive, ignore)...)
+	return diffs
+}
+
+// pairByMatcherKind pairs up routes whose exact matcher didn't match
+// anything, grouping by matcher kind (event, path-exact, path-regex,
+// path-prefix) and then pairing within each group by destination overlap
+// (see pairByDestination). Unpaired remainders are reported as plain
+// adds/removes rather than guessed at.
+func pairByMatcherKind(local, live []*v1.Route, ignore map[string]bool) []RouteDiff {
+	localByKind := groupByMatcherKind(local)
+	liveByKind := groupByMatcherKind(live)
+
+	kinds := make([]string, 0, len(localByKind))
+	for kind := range localByKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var diffs []RouteDiff
+	for _, kind := range kinds {
+		diffs = append(diffs, pairByDestination(localByKind[kind], liveByKind[kind], ignore)...)
+		delete(liveByKind, kind)
+	}
+
+	remainingKinds := make([]string, 0, len(liveByKind))
+	for kind := range liveByKind {
+		remainingKinds = append(remainingKinds, kind)
+	}
+	sort.Strings(remainingKinds)
+	for _, kind := range remainingKinds {
+		for _, r := range liveByKind[kind] {
+			diffs = append(diffs, RouteDiff{Type: Removed, Live: r})
+		}
+	}
+
+	return diffs
+}
+
+// pairByDestination pairs local and live routes of the same matcher kind
+// by how many destination names they share, greedily matching the
+// highest-overlap pair first. Routes with zero destinations in common are
+// never paired as an "edit" — an edited matcher (same backend, new path)
+// shares its destination and pairs up, while two unrelated routes that
+// merely landed in the same matcher kind (e.g. one path-prefix removed,
+// an unrelated one added) are reported as a plain remove/add instead of a
+// fabricated change.
+func pairByDestination(local, live []*v1.Route, ignore map[string]bool) []RouteDiff {
+	type candidate struct {
+		li, ri int
+		score  int
+	}
+	var candidates []candidate
+	for li, l := range local {
+		lDest, _ := destinationStrings(l)
+		lNames := make(map[string]bool)
+		for _, n := range strings.Split(lDest, ",") {
+			if n != "" {
+				lNames[n] = true
+			}
+		}
+		for ri, r := range live {
+			rDest, _ := destinationStrings(r)
+			score := 0
+			for _, n := range strings.Split(rDest, ",") {
+				if n != "" && lNames[n] {
+					score++
+				}
+			}
+			if score > 0 {
+				candidates = append(candidates, candidate{li: li, ri: ri, score: score})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	usedLocal := make(map[int]bool, len(local))
+	usedLive := make(map[int]bool, len(live))
+	var diffs []RouteDiff
+	for _, c := range candidates {
+		if usedLocal[c.li] || usedLive[c.ri] {
+			continue
+		}
+		usedLocal[c.li] = true
+		usedLive[c.ri] = true
+		if fields := compareRoute(local[c.li], live[c.ri], true, ignore); len(fields) > 0 {
+			diffs = append(diffs, RouteDiff{Type: Changed, Local: local[c.li], Live: live[c.ri], Fields: fields})
+		}
+	}
+
+	for i, l := range local {
+		if !usedLocal[i] {
+			diffs = append(diffs, RouteDiff{Type: Added, Local: l})
+		}
+	}
+	for i, r := range live {
+		if !usedLive[i] {
+			diffs = append(diffs, RouteDiff{Type: Removed, Live: r})
+		}
+	}
+	return diffs
+}
+
+func groupByMatcherKind(routes []*v1.Route) map[string][]*v1.Route {
+	byKind := make(map[string][]*v1.Route)
+	for _, r := range routes {
+		byKind[matcherKind(r)] = append(byKind[matcherKind(r)], r)
+	}
+	return byKind
+}
+
+func matcherKind(r *v1.Route) string {
+	switch m := r.GetMatcher().(type) {
+	case *v1.Route_EventMatcher:
+		return "event"
+	case *v1.Route_RequestMatcher:
+		switch m.RequestMatcher.GetPath().(type) {
+		case *v1.RequestMatcher_PathExact:
+			return "exact"
+		case *v1.RequestMatcher_PathRegex:
+			return "regex"
+		case *v1.RequestMatcher_PathPrefix:
+			return "prefix"
+		}
+	}
+	return "unknown"
+}
+
+func indexByMatcher(routes []*v1.Route) map[string]*v1.Route {
+	byKey := make(map[string]*v1.Route, len(routes))
+	for _, r := range routes {
+		byKey[matcherKey(r)] = r
+	}
+	return byKey
+}
+
+func matcherKey(r *v1.Route) string {
+	switch m := r.GetMatcher().(type) {
+	case *v1.Route_EventMatcher:
+		return "event:" + m.EventMatcher.EventType
+	case *v1.Route_RequestMatcher:
+		switch p := m.RequestMatcher.GetPath().(type) {
+		case *v1.RequestMatcher_PathExact:
+			return "exact:" + p.PathExact
+		case *v1.RequestMatcher_PathRegex:
+			return "regex:" + p.PathRegex
+		case *v1.RequestMatcher_PathPrefix:
+			return "prefix:" + p.PathPrefix
+		}
+	}
+	return fmt.Sprintf("unknown:%p", r)
+}
+
+// compareRoute diffs the non-matcher fields of local vs live, and also
+// the matcher itself when includeMatcher is set (used for routes paired
+// up by pairByMatcherKind, since their matchers are known to differ).
+func compareRoute(local, live *v1.Route, includeMatcher bool, ignore map[string]bool) []FieldDiff {
+	var diffs []FieldDiff
+
+	if includeMatcher && !ignore["matcher"] {
+		localMatcher, liveMatcher := Describe(local), Describe(live)
+		if localMatcher != liveMatcher {
+			diffs = append(diffs, FieldDiff{
+				Field: "matcher",
+				Diff:  unified("matcher", liveMatcher, localMatcher),
+			})
+		}
+	}
+
+	if !ignore["prefix_rewrite"] && local.PrefixRewrite != live.PrefixRewrite {
+		diffs = append(diffs, FieldDiff{
+			Field: "prefix_rewrite",
+			Diff:  unified("prefix_rewrite", live.PrefixRewrite, local.PrefixRewrite),
+		})
+	}
+
+	localDest, localWeights := destinationStrings(local)
+	liveDest, liveWeights := destinationStrings(live)
+	if !ignore["destination"] && localDest != liveDest {
+		diffs = append(diffs, FieldDiff{
+			Field: "destination",
+			Diff:  unified("destination", liveDest, localDest),
+		})
+	}
+	if !ignore["weights"] && localWeights != liveWeights {
+		diffs = append(diffs, FieldDiff{
+			Field: "weights",
+			Diff:  unified("weights", liveWeights, localWeights),
+		})
+	}
+
+	return diffs
+}
+
+// destinationStrings returns a stable, sorted description of a route's
+// destination set and (separately) its per-destination weights, so a
+// weight-only rebalance shows up under "weights" rather than
+// "destination".
+func destinationStrings(r *v1.Route) (dest string, weights string) {
+	if single := r.GetSingleDestination(); single != nil {
+		return destName(single.GetUpstream(), single.GetFunction()), ""
+	}
+
+	multi := r.GetMultipleDestinations()
+	names := make([]string, 0, len(multi))
+	w := make([]string, 0, len(multi))
+	for _, m := range multi {
+		name := destName(m.GetUpstream(), m.GetFunction())
+		names = append(names, name)
+		w = append(w, fmt.Sprintf("%s=%d", name, m.GetWeight()))
+	}
+	sort.Strings(names)
+	sort.Strings(w)
+	return strings.Join(names, ","), strings.Join(w, ",")
+}
+
+func destName(u *v1.UpstreamDestination, f *v1.FunctionDestination) string {
+	if u != nil {
+		return u.Name
+	}
+	if f != nil {
+		return fmt.Sprintf("%s/%s", f.UpstreamName, f.FunctionName)
+	}
+	return ""
+}
+
+func unified(field, live, local string) string {
+	d := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(live),
+		B:        difflib.SplitLines(local),
+		FromFile: "live",
+		ToFile:   "local",
+		Context:  0,
+	}
+	text, err := difflib.GetUnifiedDiffString(d)
+	if err != nil {
+		return fmt.Sprintf("%s: %q -> %q", field, live, local)
+	}
+	return text
+}
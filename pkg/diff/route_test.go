@@ -0,0 +1,120 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/solo-io/gloo-api/pkg/api/types/v1"
+)
+
+func prefixRoute(prefix, upstream string) *v1.Route {
+	return &v1.Route{
+		Matcher: &v1.Route_RequestMatcher{
+			RequestMatcher: &v1.RequestMatcher{
+				Path: &v1.RequestMatcher_PathPrefix{PathPrefix: prefix},
+			},
+		},
+		SingleDestination: &v1.Destination{
+			DestinationType: &v1.Destination_Upstream{
+				Upstream: &v1.UpstreamDestination{Name: upstream},
+			},
+		},
+	}
+}
+
+func TestRoutesMatcherEditSurfacesAsChanged(t *testing.T) {
+	local := []*v1.Route{prefixRoute("/v2", "A")}
+	live := []*v1.Route{prefixRoute("/v1", "A")}
+
+	diffs := Routes(local, live, nil)
+	if len(diffs) != 1 {
+		t.Fatalf("Routes() = %d diffs, want 1: %v", len(diffs), diffs)
+	}
+	if diffs[0].Type != Changed {
+		t.Fatalf("Routes() diff type = %s, want %s", diffs[0].Type, Changed)
+	}
+	var sawMatcher bool
+	for _, f := range diffs[0].Fields {
+		if f.Field == "matcher" {
+			sawMatcher = true
+		}
+	}
+	if !sawMatcher {
+		t.Errorf("Routes() diff fields = %v, want a matcher field diff", diffs[0].Fields)
+	}
+}
+
+func TestRoutesIgnoreMatcherSuppressesEdit(t *testing.T) {
+	local := []*v1.Route{prefixRoute("/v2", "A")}
+	live := []*v1.Route{prefixRoute("/v1", "A")}
+
+	diffs := Routes(local, live, ParseIgnore("matcher"))
+	if len(diffs) != 0 {
+		t.Errorf("Routes() with --ignore=matcher = %v, want no diffs", diffs)
+	}
+}
+
+func TestRoutesUnrelatedSameKindRoutesAreAddPlusRemove(t *testing.T) {
+	// Live has /v2 -> B removed; local adds an unrelated /v3 -> C. They
+	// share a matcher kind (path-prefix) but no destination, so they must
+	// not be fused into a single fabricated "edit".
+	local := []*v1.Route{prefixRoute("/v3", "C")}
+	live := []*v1.Route{prefixRoute("/v2", "B")}
+
+	diffs := Routes(local, live, nil)
+	if len(diffs) != 2 {
+		t.Fatalf("Routes() = %d diffs, want 2 (one added, one removed): %v", len(diffs), diffs)
+	}
+	types := map[ChangeType]bool{}
+	for _, d := range diffs {
+		types[d.Type] = true
+		if d.Type == Changed {
+			t.Errorf("Routes() fabricated a Changed diff for unrelated routes: %v", d)
+		}
+	}
+	if !types[Added] || !types[Removed] {
+		t.Errorf("Routes() types = %v, want Added and Removed", types)
+	}
+}
+
+func TestRoutesPairsByDestinationWhenMultipleLeftoversShareAKind(t *testing.T) {
+	// Two path-prefix routes on each side. /v1->A edited to /v1-new->A
+	// (same destination, should pair as Changed); /v2->B is simply gone,
+	// /v3->C is simply new (no shared destination with anything live).
+	local := []*v1.Route{
+		prefixRoute("/v1-new", "A"),
+		prefixRoute("/v3", "C"),
+	}
+	live := []*v1.Route{
+		prefixRoute("/v1", "A"),
+		prefixRoute("/v2", "B"),
+	}
+
+	diffs := Routes(local, live, nil)
+
+	var changed, added, removed int
+	for _, d := range diffs {
+		switch d.Type {
+		case Changed:
+			changed++
+			if Describe(d.Live) != "path prefix /v1" || Describe(d.Local) != "path prefix /v1-new" {
+				t.Errorf("Routes() paired the wrong routes as Changed: local=%s live=%s", Describe(d.Local), Describe(d.Live))
+			}
+		case Added:
+			added++
+		case Removed:
+			removed++
+		}
+	}
+	if changed != 1 || added != 1 || removed != 1 {
+		t.Fatalf("Routes() = %d changed, %d added, %d removed; want 1 each: %v", changed, added, removed, diffs)
+	}
+}
+
+func TestRoutesNoDiffsWhenIdentical(t *testing.T) {
+	local := []*v1.Route{prefixRoute("/v1", "A")}
+	live := []*v1.Route{prefixRoute("/v1", "A")}
+
+	if diffs := Routes(local, live, nil); len(diffs) != 0 {
+		t.Errorf("Routes() = %v, want no diffs for identical routes", diffs)
+	}
+}